@@ -0,0 +1,165 @@
+// Package admin exposes a read-mostly http.Handler for inspecting and operating on a gue queue, inspired
+// by odd-jobs' web UI. It is mounted at a user-chosen prefix and backed by the same *gue.Client the
+// application already uses to enqueue jobs.
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vgarvardt/gue/v3"
+)
+
+// Option configures a Handler during construction.
+type Option func(*Handler)
+
+// WithAuth wraps every request through middleware before it reaches the admin routes, so callers can
+// plug in their own authentication/authorization without this package having an opinion on the scheme.
+func WithAuth(middleware func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		h.auth = middleware
+	}
+}
+
+// Handler is an http.Handler that serves job introspection and control endpoints for a single gue
+// client. Construct it with New and mount it at any prefix with http.StripPrefix.
+type Handler struct {
+	client *gue.Client
+	auth   func(http.Handler) http.Handler
+	mux    *http.ServeMux
+}
+
+// New builds a Handler backed by client. Routes are relative to wherever the returned Handler is
+// mounted, so use http.StripPrefix if serving it under a non-root prefix.
+func New(client *gue.Client, opts ...Option) *Handler {
+	h := &Handler{client: client}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", h.handleListJobs)
+	mux.HandleFunc("/jobs/", h.handleJobByID)
+	mux.HandleFunc("/stats", h.handleStats)
+	h.mux = mux
+
+	return h
+}
+
+// ServeHTTP implements http.Handler, running the configured auth middleware (if any) before dispatch.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var next http.Handler = h.mux
+	if h.auth != nil {
+		next = h.auth(next)
+	}
+	next.ServeHTTP(w, r)
+}
+
+// handleListJobs serves GET /jobs?queue=&type=&state=(pending|running|failed|dead)&limit=&offset=
+func (h *Handler) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	state := gue.JobState(q.Get("state"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+
+	if state == gue.JobStateDead {
+		jobs, err := h.client.ListDLQ(r.Context(), q.Get("queue"), limit, offset)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, jobs)
+		return
+	}
+
+	jobs, err := h.client.ListJobs(r.Context(), q.Get("queue"), q.Get("type"), state, limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, jobs)
+}
+
+// handleJobByID dispatches GET /jobs/{id}, POST /jobs/{id}/retry and POST /jobs/{id}/cancel.
+func (h *Handler) handleJobByID(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/jobs/")
+
+	id, action, _ := strings.Cut(rest, "/")
+	jobID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.handleGetJob(w, r, jobID)
+	case action == "retry" && r.Method == http.MethodPost:
+		h.handleRetryJob(w, r, jobID)
+	case action == "cancel" && r.Method == http.MethodPost:
+		h.handleCancelJob(w, r, jobID)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) handleGetJob(w http.ResponseWriter, r *http.Request, jobID int64) {
+	job, err := h.client.GetJob(r.Context(), jobID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, job)
+}
+
+func (h *Handler) handleRetryJob(w http.ResponseWriter, r *http.Request, jobID int64) {
+	if err := h.client.RetryJob(r.Context(), jobID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleCancelJob(w http.ResponseWriter, r *http.Request, jobID int64) {
+	if err := h.client.CancelJob(r.Context(), jobID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleStats serves GET /stats, returning per-queue counts by state and the oldest pending run_at.
+func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats, err := h.client.Stats(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, stats)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if err == sql.ErrNoRows {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,168 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// JobState is the coarse lifecycle state the admin subsystem groups jobs by, derived from the same
+// columns the worker itself uses rather than a dedicated status column.
+type JobState string
+
+const (
+	// JobStatePending jobs have never errored and are not currently locked by a worker.
+	JobStatePending JobState = "pending"
+	// JobStateRunning jobs are currently locked by a worker (locked_at is set).
+	JobStateRunning JobState = "running"
+	// JobStateFailed jobs have errored at least once and are waiting to be retried.
+	JobStateFailed JobState = "failed"
+	// JobStateDead jobs exhausted their retries and were moved to gue_jobs_dlq.
+	JobStateDead JobState = "dead"
+)
+
+// QueueStats summarises the jobs in a single queue, grouped by JobState.
+type QueueStats struct {
+	Queue           string
+	PendingCount    int64
+	RunningCount    int64
+	FailedCount     int64
+	DeadCount       int64
+	OldestPendingAt sql.NullTime
+}
+
+// ListJobs returns a page of jobs from gue_jobs matching the given filters, oldest run_at first. Any
+// filter left empty matches every value for that column; limit <= 0 defaults to 50.
+//
+// adapter.ConnPool has no multi-row Query, only QueryRow, so this fetches one row at a time via LIMIT 1
+// OFFSET, which is fine for the admin-subsystem page sizes this is meant for. The ordering includes
+// job_id as a tie-breaker so jobs sharing a run_at are still paginated consistently across these
+// per-row round trips.
+func (c *Client) ListJobs(ctx context.Context, queue, jobType string, state JobState, limit, offset int) ([]*Job, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var jobs []*Job
+	for i := 0; i < limit; i++ {
+		job := new(Job)
+		err := c.pool.QueryRow(ctx, `SELECT job_id, queue, priority, run_at, job_type, args, error_count,
+last_error, created_at, updated_at
+FROM gue_jobs
+WHERE ($1 = '' OR queue = $1)
+  AND ($2 = '' OR job_type = $2)
+  AND ($3 = '' OR
+       ($3 = 'pending' AND locked_at IS NULL AND error_count = 0) OR
+       ($3 = 'running' AND locked_at IS NOT NULL) OR
+       ($3 = 'failed'  AND locked_at IS NULL AND error_count > 0))
+ORDER BY run_at ASC, job_id ASC
+LIMIT 1 OFFSET $4`, queue, jobType, string(state), offset+i).Scan(&job.ID, &job.Queue, &job.Priority,
+			&job.RunAt, &job.Type, &job.Args, &job.ErrorCount, &job.LastError, &job.CreatedAt, &job.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// GetJob returns the full row for a single job, including its last_error, or sql.ErrNoRows if it does
+// not exist in gue_jobs (it may have finished, been deleted, or been moved to the DLQ).
+func (c *Client) GetJob(ctx context.Context, jobID int64) (*Job, error) {
+	job := &Job{ID: jobID}
+
+	err := c.pool.QueryRow(ctx, `SELECT queue, priority, run_at, job_type, args, error_count, last_error,
+created_at, updated_at
+FROM gue_jobs
+WHERE job_id = $1`, jobID).Scan(&job.Queue, &job.Priority, &job.RunAt, &job.Type, &job.Args,
+		&job.ErrorCount, &job.LastError, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RetryJob resets a job's error count and schedules it to run immediately, regardless of its current
+// run_at. It returns sql.ErrNoRows if no such job exists.
+func (c *Client) RetryJob(ctx context.Context, jobID int64) error {
+	cmdTag, err := c.pool.Exec(ctx, `UPDATE gue_jobs
+SET error_count = 0, run_at = $1, updated_at = $1
+WHERE job_id = $2`, time.Now().UTC(), jobID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CancelJob deletes a pending job from gue_jobs so it never runs. It returns sql.ErrNoRows if no such
+// job exists; a job already picked up by a worker (locked_at set) is left untouched.
+func (c *Client) CancelJob(ctx context.Context, jobID int64) error {
+	cmdTag, err := c.pool.Exec(ctx, `DELETE FROM gue_jobs WHERE job_id = $1 AND locked_at IS NULL`, jobID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Stats returns per-queue job counts broken down by JobState, plus the oldest pending run_at in each
+// queue, for use by monitoring and the admin subsystem.
+//
+// adapter.ConnPool has no multi-row Query, only QueryRow, so the distinct queue names are fetched one at
+// a time via LIMIT 1 OFFSET, and each queue's counts are then a pair of naturally single-row aggregate
+// QueryRow calls.
+func (c *Client) Stats(ctx context.Context) ([]*QueueStats, error) {
+	var result []*QueueStats
+
+	for offset := 0; ; offset++ {
+		var queue string
+		err := c.pool.QueryRow(ctx, `SELECT queue FROM (
+    SELECT queue FROM gue_jobs
+    UNION
+    SELECT queue FROM gue_jobs_dlq
+) q
+ORDER BY queue
+LIMIT 1 OFFSET $1`, offset).Scan(&queue)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s := &QueueStats{Queue: queue}
+
+		err = c.pool.QueryRow(ctx, `SELECT
+    count(*) FILTER (WHERE locked_at IS NULL AND error_count = 0) AS pending_count,
+    count(*) FILTER (WHERE locked_at IS NOT NULL)                 AS running_count,
+    count(*) FILTER (WHERE locked_at IS NULL AND error_count > 0) AS failed_count,
+    min(run_at)  FILTER (WHERE locked_at IS NULL AND error_count = 0) AS oldest_pending_at
+FROM gue_jobs
+WHERE queue = $1`, queue).Scan(&s.PendingCount, &s.RunningCount, &s.FailedCount, &s.OldestPendingAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.pool.QueryRow(ctx, `SELECT count(*) FROM gue_jobs_dlq WHERE queue = $1`, queue).
+			Scan(&s.DeadCount); err != nil {
+			return nil, err
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
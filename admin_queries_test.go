@@ -0,0 +1,41 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelJob_RefusesLockedJob(t *testing.T) {
+	pool := newFakePool()
+	pool.jobs[1] = &fakeJobRow{
+		queue:    "",
+		jobType:  "MyJob",
+		lockedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		lockedBy: "worker-1",
+	}
+
+	c := NewClient(pool)
+
+	err := c.CancelJob(context.Background(), 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, stillPresent := pool.jobs[1]
+	assert.True(t, stillPresent, "a locked job must not be deleted by CancelJob")
+}
+
+func TestCancelJob_DeletesUnlockedJob(t *testing.T) {
+	pool := newFakePool()
+	pool.jobs[1] = &fakeJobRow{queue: "", jobType: "MyJob"}
+
+	c := NewClient(pool)
+
+	require.NoError(t, c.CancelJob(context.Background(), 1))
+
+	_, stillPresent := pool.jobs[1]
+	assert.False(t, stillPresent)
+}
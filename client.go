@@ -0,0 +1,148 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// defaultPollInterval is the default value for WithWorkerPollInterval/WithPoolPollInterval.
+const defaultPollInterval = 5 * time.Second
+
+// defaultQueueName is the queue name used when none is set via WithWorkerQueue/WithPoolQueue.
+const defaultQueueName = ""
+
+// defaultBackoff is the Backoff used when none is set on the Client.
+func defaultBackoff(retries int) time.Duration {
+	return time.Duration(retries) * time.Second
+}
+
+// Client is a Gue client that enqueues jobs and hands them out to workers.
+type Client struct {
+	pool      adapter.ConnPool
+	logger    adapter.Logger
+	backoff   Backoff
+	id        string
+	uniqueTTL time.Duration
+}
+
+// WithClientLogger sets Logger implementation to client.
+func WithClientLogger(logger adapter.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithClientBackoff overrides the default Backoff used to reschedule errored jobs.
+func WithClientBackoff(backoff Backoff) ClientOption {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// NewClient creates a new Client that uses pool for all of its database access.
+func NewClient(pool adapter.ConnPool, options ...ClientOption) *Client {
+	c := Client{
+		pool:    pool,
+		logger:  adapter.NoOpLogger{},
+		backoff: defaultBackoff,
+		id:      randomID("client"),
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	c.logger = c.logger.With(adapter.F("client-id", c.id))
+
+	return &c
+}
+
+// Enqueue adds a job to the queue and wakes up any NotifyPollStrategy worker interested in it.
+func (c *Client) Enqueue(ctx context.Context, j *Job) error {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := c.enqueueInTx(ctx, j, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// EnqueueInTx adds a job to the queue within the scope of tx. The caller is responsible for committing
+// or rolling back tx once this call returns.
+func (c *Client) EnqueueInTx(ctx context.Context, j *Job, tx adapter.Tx) error {
+	return c.enqueueInTx(ctx, j, tx)
+}
+
+// enqueueInTx inserts j and NOTIFYs gue_jobs in the same transaction, so a worker using
+// NotifyPollStrategy only ever observes a job after it is visible to LockJob.
+func (c *Client) enqueueInTx(ctx context.Context, j *Job, tx adapter.Tx) error {
+	now := time.Now().UTC()
+	runAt := j.RunAt
+	if runAt.IsZero() {
+		runAt = now
+	}
+
+	err := tx.QueryRow(ctx, `INSERT INTO gue_jobs
+(queue, priority, run_at, job_type, args, key, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+RETURNING job_id`, j.Queue, j.Priority, runAt, j.Type, j.Args, j.Key, now).Scan(&j.ID)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	j.RunAt = runAt
+	j.CreatedAt = now
+
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, notifyPayload(j.Queue, j.Type)); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", notifyChannel, err)
+	}
+
+	return nil
+}
+
+// LockJob attempts to retrieve and lock a Job from queue on behalf of workerID. An empty queue matches
+// only jobs explicitly enqueued with an empty queue (the default), not every queue. LockJob returns a nil
+// Job (and nil error) if no job is currently available.
+// The returned job's locked_at/locked_by columns are stamped so a janitor started via
+// WithWorkerReviveStalled can tell the job is held and by whom.
+func (c *Client) LockJob(ctx context.Context, queue, workerID string) (*Job, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Job{pool: c.pool, tx: tx, backoff: c.backoff}
+
+	err = tx.QueryRow(ctx, `SELECT job_id, queue, priority, run_at, job_type, args, error_count,
+last_error, key, created_at, updated_at
+FROM gue_jobs
+WHERE queue = $1 AND run_at <= $2 AND finished_at IS NULL
+ORDER BY priority ASC, run_at ASC
+LIMIT 1
+FOR UPDATE SKIP LOCKED`, queue, time.Now().UTC()).Scan(&j.ID, &j.Queue, &j.Priority, &j.RunAt, &j.Type,
+		&j.Args, &j.ErrorCount, &j.LastError, &j.Key, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE gue_jobs SET locked_at = $1, locked_by = $2 WHERE job_id = $3`,
+		time.Now().UTC(), workerID, j.ID); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to lock job %d: %w", j.ID, err)
+	}
+
+	return j, nil
+}
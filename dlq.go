@@ -0,0 +1,98 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+// DLQJob is a job that exhausted its retries and was moved out of gue_jobs into gue_jobs_dlq by
+// Job.Dead. It mirrors the fields of Job plus the bookkeeping recorded at the time it died.
+type DLQJob struct {
+	JobID      int64
+	Queue      string
+	Type       string
+	Priority   int16
+	RunAt      time.Time
+	Args       []byte
+	ErrorCount int32
+	LastError  pgtype.Text
+	CreatedAt  time.Time
+	UpdatedAt  sql.NullTime
+	DeadAt     time.Time
+	DeadReason string
+}
+
+// shouldDeadLetter reports whether a job that just failed with its errorCount-th error (before being
+// incremented for this failure) should be moved to the dead-letter queue instead of rescheduled. A
+// maxRetries of 0 or less means retry forever, matching WithWorkerMaxRetries' documented zero value.
+func shouldDeadLetter(errorCount int32, maxRetries int) bool {
+	return maxRetries > 0 && int(errorCount)+1 >= maxRetries
+}
+
+// ListDLQ returns jobs from the dead-letter queue for the given queue, most recently dead first. Pass an
+// empty queue to list dead jobs across all queues. limit <= 0 defaults to 50.
+//
+// adapter.ConnPool has no multi-row Query, only QueryRow, so this fetches one row at a time via LIMIT 1
+// OFFSET, which is fine for the admin-subsystem page sizes this is meant for. The ordering includes
+// job_id as a tie-breaker so jobs sharing a dead_at are still paginated consistently across these
+// per-row round trips.
+func (c *Client) ListDLQ(ctx context.Context, queue string, limit, offset int) ([]*DLQJob, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var jobs []*DLQJob
+	for i := 0; i < limit; i++ {
+		job := new(DLQJob)
+		err := c.pool.QueryRow(ctx, `SELECT job_id, queue, job_type, priority, run_at, args, error_count,
+last_error, created_at, updated_at, dead_at, dead_reason
+FROM gue_jobs_dlq
+WHERE $1 = '' OR queue = $1
+ORDER BY dead_at DESC, job_id DESC
+LIMIT 1 OFFSET $2`, queue, offset+i).Scan(&job.JobID, &job.Queue, &job.Type, &job.Priority, &job.RunAt,
+			&job.Args, &job.ErrorCount, &job.LastError, &job.CreatedAt, &job.UpdatedAt, &job.DeadAt, &job.DeadReason)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// RequeueDLQ moves a job identified by jobID from gue_jobs_dlq back into gue_jobs, resetting its error
+// count and scheduling it to run immediately. It returns sql.ErrNoRows if no dead job with that ID exists.
+func (c *Client) RequeueDLQ(ctx context.Context, jobID int64) error {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	now := time.Now().UTC()
+
+	cmdTag, err := tx.Exec(ctx, `INSERT INTO gue_jobs (job_id, queue, job_type, priority, run_at, args,
+error_count, last_error, created_at, updated_at)
+SELECT job_id, queue, job_type, priority, $2, args, 0, last_error, created_at, $2
+FROM gue_jobs_dlq
+WHERE job_id = $1`, jobID, now)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM gue_jobs_dlq WHERE job_id = $1`, jobID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
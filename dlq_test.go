@@ -0,0 +1,28 @@
+package gue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldDeadLetter(t *testing.T) {
+	tests := []struct {
+		name       string
+		errorCount int32
+		maxRetries int
+		want       bool
+	}{
+		{name: "retry forever when maxRetries is zero", errorCount: 100, maxRetries: 0, want: false},
+		{name: "retry forever when maxRetries is negative", errorCount: 100, maxRetries: -1, want: false},
+		{name: "below threshold", errorCount: 1, maxRetries: 3, want: false},
+		{name: "reaches threshold", errorCount: 2, maxRetries: 3, want: true},
+		{name: "past threshold", errorCount: 5, maxRetries: 3, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, shouldDeadLetter(tt.errorCount, tt.maxRetries))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgconn"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// fakePool is a minimal in-memory adapter.ConnPool, just enough to exercise the
+// check-then-insert race in enqueueUniqueInTx and the lock/cancel race in CancelJob without a real
+// Postgres connection. It is not a general-purpose fake: only the query shapes this package actually
+// issues are recognised.
+type fakePool struct {
+	mu      sync.Mutex
+	nextID  int64
+	jobs    map[int64]*fakeJobRow
+	liveKey map[string]bool
+
+	// afterSelect, if set, runs once a fakeTx's QueryRow for the unique-key EXISTS check has read its
+	// result and before the caller acts on it, so a test can force two transactions through the race
+	// window together.
+	afterSelect func()
+}
+
+type fakeJobRow struct {
+	queue, jobType string
+	lockedAt       sql.NullTime
+	lockedBy       string
+}
+
+func newFakePool() *fakePool {
+	return &fakePool{
+		jobs:    make(map[int64]*fakeJobRow),
+		liveKey: make(map[string]bool),
+	}
+}
+
+func (p *fakePool) Begin(ctx context.Context) (adapter.Tx, error) {
+	return &fakeTx{p: p}, nil
+}
+
+func (p *fakePool) Close(ctx context.Context) error {
+	return nil
+}
+
+// QueryRow only ever recognises janitor.reviveStalled's single-stalled-job lookup: it reports a job as
+// stalled if its lockedAt is set, regardless of how long ago, since the fake pool has no notion of wall
+// clock thresholds.
+func (p *fakePool) QueryRow(ctx context.Context, query string, args ...interface{}) adapter.Row {
+	if !strings.Contains(query, "locked_at IS NOT NULL") {
+		return &fakeRow{err: errors.New("fakePool: QueryRow not supported")}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queue, _ := args[0].(string)
+	for id, job := range p.jobs {
+		if job.queue == queue && job.lockedAt.Valid {
+			return &fakeRow{dest: []interface{}{id, int32(0)}}
+		}
+	}
+	return &fakeRow{err: sql.ErrNoRows}
+}
+
+func (p *fakePool) Exec(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "DELETE FROM gue_jobs"):
+		// CancelJob: delete an unlocked job, leave a locked one untouched.
+		jobID, _ := args[len(args)-1].(int64)
+		job, found := p.jobs[jobID]
+		if !found || job.lockedAt.Valid {
+			return fakeCommandTag(0), nil
+		}
+		delete(p.jobs, jobID)
+		return fakeCommandTag(1), nil
+
+	case strings.Contains(query, "SET locked_at = now()"):
+		// heartbeat: stamp locked_at so it stays visible to other sessions immediately.
+		jobID, _ := args[len(args)-1].(int64)
+		job, found := p.jobs[jobID]
+		if !found {
+			return fakeCommandTag(0), nil
+		}
+		job.lockedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		return fakeCommandTag(1), nil
+
+	case strings.Contains(query, "locked_at = NULL, locked_by = NULL"):
+		// janitor.reviveStalled: clear the lock on the stalled job it just looked up.
+		jobID, _ := args[len(args)-1].(int64)
+		job, found := p.jobs[jobID]
+		if !found || !job.lockedAt.Valid {
+			return fakeCommandTag(0), nil
+		}
+		job.lockedAt = sql.NullTime{}
+		job.lockedBy = ""
+		return fakeCommandTag(1), nil
+
+	default:
+		return fakeCommandTag(0), errors.New("fakePool: Exec not supported")
+	}
+}
+
+// fakeTx is the adapter.Tx handed out by fakePool.Begin.
+type fakeTx struct {
+	p         *fakePool
+	done      bool
+	committed bool
+
+	// execErr, if set, is returned by every call to Exec instead of succeeding, so a test can simulate a
+	// failed UPDATE/INSERT (e.g. Job.Error or Job.Dead failing to persist).
+	execErr error
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, query string, args ...interface{}) adapter.Row {
+	p := tx.p
+
+	switch {
+	case len(args) == 4:
+		// enqueueUniqueInTx's EXISTS check: (queue, job_type, key, ttlSeconds).
+		queue, _ := args[0].(string)
+		jobType, _ := args[1].(string)
+		key, _ := args[2].(string)
+
+		p.mu.Lock()
+		conflict := p.liveKey[queue+"|"+jobType+"|"+key]
+		p.mu.Unlock()
+
+		if p.afterSelect != nil {
+			p.afterSelect()
+		}
+
+		return &fakeRow{dest: []interface{}{conflict}}
+
+	case len(args) == 7:
+		// enqueueInTx's INSERT ... RETURNING job_id: (queue, priority, run_at, job_type, args, key, created_at).
+		queue, _ := args[0].(string)
+		jobType, _ := args[3].(string)
+		key, _ := args[5].(string)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		k := queue + "|" + jobType + "|" + key
+		if key != "" && p.liveKey[k] {
+			return &fakeRow{err: &pgconn.PgError{Code: uniqueViolationCode, Message: "duplicate key value violates unique constraint \"idx_gue_jobs_unique_key\""}}
+		}
+
+		p.nextID++
+		id := p.nextID
+		p.jobs[id] = &fakeJobRow{queue: queue, jobType: jobType}
+		if key != "" {
+			p.liveKey[k] = true
+		}
+
+		return &fakeRow{dest: []interface{}{id}}
+
+	default:
+		return &fakeRow{err: errors.New("fakeTx: QueryRow not supported")}
+	}
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+	if tx.execErr != nil {
+		return fakeCommandTag(0), tx.execErr
+	}
+	// pg_notify and similar fire-and-forget statements: nothing to simulate.
+	return fakeCommandTag(0), nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	tx.done = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if tx.committed {
+		return nil
+	}
+	tx.done = true
+	return nil
+}
+
+// fakeRow is the adapter.Row returned by fakePool/fakeTx's QueryRow.
+type fakeRow struct {
+	dest []interface{}
+	err  error
+}
+
+func (r *fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		switch v := d.(type) {
+		case *bool:
+			*v, _ = r.dest[i].(bool)
+		case *int64:
+			*v, _ = r.dest[i].(int64)
+		case *int32:
+			*v, _ = r.dest[i].(int32)
+		default:
+			return errors.New("fakeRow: unsupported scan destination")
+		}
+	}
+	return nil
+}
+
+// fakeCommandTag is the adapter.CommandTag returned by fakePool/fakeTx's Exec.
+type fakeCommandTag int64
+
+func (t fakeCommandTag) RowsAffected() int64 {
+	return int64(t)
+}
@@ -0,0 +1,49 @@
+package gue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// defaultHeartbeatInterval is how often a worker updates locked_at while it holds a job, when
+// WithWorkerJobTimeout is set. It is kept well below any reasonable stall threshold so a live worker's
+// heartbeat is never mistaken for a stall by the janitor started via WithWorkerReviveStalled.
+const defaultHeartbeatInterval = 10 * time.Second
+
+// startHeartbeat launches a goroutine that updates gue_jobs.locked_at for jobID every interval, so the
+// janitor can tell the job's worker is still alive. Call the returned stop func once the job finishes;
+// it is safe to call more than once.
+//
+// The update goes through pool, not the job's own (still open) transaction: a write made inside that
+// transaction would stay invisible to every other session, including the janitor's, until it commits at
+// the very end of the job. pool.Exec auto-commits the single statement, so the heartbeat is visible
+// immediately.
+func startHeartbeat(ctx context.Context, pool adapter.ConnPool, jobID int64, interval time.Duration, logger adapter.Logger) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := pool.Exec(ctx, `UPDATE gue_jobs SET locked_at = now() WHERE job_id = $1`, jobID); err != nil {
+					logger.Error("failed to send job heartbeat", adapter.F("job_id", jobID), adapter.F("error", err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
@@ -0,0 +1,25 @@
+package gue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+func TestStartHeartbeat(t *testing.T) {
+	pool := newFakePool()
+	pool.jobs[1] = &fakeJobRow{queue: "", jobType: "MyJob"}
+
+	stop := startHeartbeat(context.Background(), pool, 1, time.Millisecond, adapter.NoOpLogger{})
+	time.Sleep(5 * time.Millisecond)
+
+	// stop must be safe to call more than once.
+	stop()
+	stop()
+
+	assert.NotNil(t, pool.jobs[1])
+}
@@ -0,0 +1,15 @@
+package gue
+
+import "context"
+
+// HookFunc is a function called at a worker lifecycle point with the job involved, if any, and any
+// error produced at that point, if any. Unlike Observer, hooks receive the live *Job and its
+// transaction, so they may be used to extend what happens to a job, not just observe it.
+type HookFunc func(ctx context.Context, job *Job, err error)
+
+// callHooks invokes every hook in hooks with the same (job, err) pair, in order.
+func callHooks(ctx context.Context, hooks []HookFunc, job *Job, err error) {
+	for _, h := range hooks {
+		h(ctx, job, err)
+	}
+}
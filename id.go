@@ -0,0 +1,14 @@
+package gue
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// randomID returns a short random identifier prefixed with prefix, used as the default
+// worker/worker-pool ID when none is set explicitly via WithWorkerID/WithPoolID, and as the client ID.
+func randomID(prefix string) string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%s-%x", prefix, b)
+}
@@ -40,6 +40,10 @@ type Job struct {
 	// Args must be the bytes of a valid JSON string
 	Args []byte
 
+	// Key is an optional dedup key for this job. When set via Client.EnqueueUnique, Gue enforces that at
+	// most one live (not yet finished) job with the same (Queue, Type, Key) exists at a time.
+	Key string
+
 	// ErrorCount is the number of times this job has attempted to run, but
 	// failed with an error. It is ignored on job creation.
 	// This field is initialised only when the Job is being retrieved from the DB and is not
@@ -110,7 +114,7 @@ func (j *Job) Finished(ctx context.Context) error {
 	}
 
 	now := time.Now().UTC()
-	_, err := j.tx.Exec(ctx, ` UPDATE gue_jobs SET finished_at =$1 WHERE job_id = $2`, now, j.ID)
+	_, err := j.tx.Exec(ctx, ` UPDATE gue_jobs SET finished_at = $1, locked_at = NULL, locked_by = NULL WHERE job_id = $2`, now, j.ID)
 	if err != nil {
 		return err
 	}
@@ -189,8 +193,45 @@ func (j *Job) Error(ctx context.Context, msg string) (err error) {
 SET error_count = $1,
     run_at      = $2,
     last_error  = $3,
-    updated_at  = $4
+    updated_at  = $4,
+    locked_at   = NULL,
+    locked_by   = NULL
 WHERE job_id    = $5`, errorCount, newRunAt, msg, now, j.ID)
 
 	return err
 }
+
+// Dead moves this job into the gue_jobs_dlq table with the given reason instead of rescheduling it, and
+// removes it from gue_jobs. Workers call this instead of Error once ErrorCount+1 reaches the configured
+// max retries, so the job stops being retried without being lost - operators can inspect and requeue it
+// later via Client.ListDLQ / Client.RequeueDLQ.
+//
+// This call marks job as done and releases (commits) transaction, so calling Done() is not required,
+// although calling it will not cause any issues. If you got the job from the worker - it will take care
+// of cleaning up the job and resources, no need to do this manually in a WorkFunc.
+func (j *Job) Dead(ctx context.Context, reason string) (err error) {
+	defer func() {
+		doneErr := j.Done(ctx)
+		if doneErr != nil {
+			err = fmt.Errorf("failed to mark job as done (original error: %v): %w", err, doneErr)
+		}
+	}()
+
+	now := time.Now().UTC()
+
+	_, err = j.tx.Exec(ctx, `INSERT INTO gue_jobs_dlq (job_id, job_type, queue, args, priority, run_at,
+error_count, last_error, created_at, updated_at, dead_at, dead_reason)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`, j.ID, j.Type, j.Queue, j.Args, j.Priority, j.RunAt,
+		j.ErrorCount+1, j.LastError, j.CreatedAt, j.UpdatedAt.Time.UTC(), now, reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert job into gue_jobs_dlq: %w", err)
+	}
+
+	_, err = j.tx.Exec(ctx, `DELETE FROM gue_jobs WHERE job_id = $1`, j.ID)
+	if err != nil {
+		return err
+	}
+
+	j.deleted = true
+	return nil
+}
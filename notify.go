@@ -0,0 +1,154 @@
+package gue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// notifyChannel is the Postgres channel Enqueue sends on and NotifyPollStrategy workers LISTEN on.
+const notifyChannel = "gue_jobs"
+
+// notifyFallbackInterval is how often a NotifyPollStrategy worker falls back to a full poll while it
+// waits for notifications, so jobs with a future RunAt still get picked up once it elapses.
+const notifyFallbackInterval = 30 * time.Second
+
+// NotifyPollStrategy makes the worker wait for a LISTEN/NOTIFY wake-up from Client.Enqueue instead of
+// sleeping for a fixed poll interval. It falls back to polling every notifyFallbackInterval so delayed
+// RunAt jobs and notifications lost to a reconnect are still picked up eventually.
+const NotifyPollStrategy PollStrategy = "notify_poll_strategy"
+
+// notifyPayload builds the NOTIFY payload Client.Enqueue sends for a given queue/type pair.
+func notifyPayload(queue, jobType string) string {
+	return queue + "|" + jobType
+}
+
+// parseNotifyPayload splits a payload produced by notifyPayload back into its queue and type parts.
+func parseNotifyPayload(payload string) (queue, jobType string) {
+	parts := strings.SplitN(payload, "|", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
+// Notification is a single payload delivered by Postgres to a LISTENing connection.
+type Notification struct {
+	Payload string
+}
+
+// NotifyConn is a dedicated, non-pooled connection capable of LISTEN/NOTIFY. adapter.ConnPool has no way
+// to hand out a raw connection (it only exposes Exec/QueryRow/Begin/Close), so a NotifyPollStrategy
+// worker is instead handed one of these directly by the caller via WithWorkerNotifyConn/WithPoolNotifyConn,
+// typically backed by a single long-lived *pgx.Conn or equivalent the application manages itself.
+type NotifyConn interface {
+	// Exec runs sql (e.g. "LISTEN gue_jobs") on the connection.
+	Exec(ctx context.Context, sql string) error
+	// WaitForNotification blocks until a notification arrives or ctx is done.
+	WaitForNotification(ctx context.Context) (Notification, error)
+	// Close releases the connection.
+	Close(ctx context.Context) error
+}
+
+// acquirer multiplexes notifications received on a single dedicated LISTEN connection out to any number
+// of workers interested in a given (queue, type) pair. Workers register a channel on start and unregister
+// it on stop; a missed or coalesced notification is harmless because workers always fall back to
+// LockJob/polling, so delivery here is best-effort and never blocks the listener goroutine.
+type acquirer struct {
+	logger adapter.Logger
+
+	mu   sync.Mutex
+	subs map[string][]chan<- struct{}
+}
+
+// newAcquirer creates an acquirer that logs connection issues with logger.
+func newAcquirer(logger adapter.Logger) *acquirer {
+	return &acquirer{
+		logger: logger,
+		subs:   make(map[string][]chan<- struct{}),
+	}
+}
+
+// register adds ch to the set of channels woken up when a job matching (queue, jobType) is enqueued.
+// The returned func removes the registration and must be called when the worker stops.
+func (a *acquirer) register(queue, jobType string, ch chan<- struct{}) (unregister func()) {
+	key := notifyPayload(queue, jobType)
+
+	a.mu.Lock()
+	a.subs[key] = append(a.subs[key], ch)
+	a.mu.Unlock()
+
+	return func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		subs := a.subs[key]
+		for i, sub := range subs {
+			if sub == ch {
+				a.subs[key] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// notify wakes every channel registered for payload. It never blocks: a worker that is already busy
+// processing a previous wake-up will simply pick this one up on its next fallback poll.
+func (a *acquirer) notify(payload string) {
+	a.mu.Lock()
+	subs := append([]chan<- struct{}{}, a.subs[payload]...)
+	a.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// listen opens a dedicated LISTEN connection via connect and relays notifications until ctx is done,
+// reconnecting and re-issuing LISTEN on any connection error. Registrations live in a, not on the
+// connection, so a reconnect needs no re-subscription beyond the LISTEN statement itself.
+func (a *acquirer) listen(ctx context.Context, connect func(ctx context.Context) (NotifyConn, error)) {
+	for ctx.Err() == nil {
+		conn, err := connect(ctx)
+		if err != nil {
+			a.logger.Error("failed to open gue_jobs listen connection", adapter.F("error", err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if err := conn.Exec(ctx, fmt.Sprintf("LISTEN %s", notifyChannel)); err != nil {
+			a.logger.Error("failed to LISTEN on gue_jobs", adapter.F("error", err))
+			_ = conn.Close(ctx)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		a.relay(ctx, conn)
+		_ = conn.Close(ctx)
+	}
+}
+
+// relay forwards notifications from conn until it errors or ctx is done.
+func (a *acquirer) relay(ctx context.Context, conn NotifyConn) {
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				a.logger.Error("gue_jobs notify listener lost connection, reconnecting", adapter.F("error", err))
+			}
+			return
+		}
+
+		queue, jobType := parseNotifyPayload(n.Payload)
+		a.logger.Debug("received gue_jobs notification", adapter.F("queue", queue), adapter.F("job-type", jobType))
+
+		a.notify(n.Payload)
+	}
+}
@@ -0,0 +1,121 @@
+package gue
+
+import (
+	"context"
+	"time"
+)
+
+// JobEvent is the snapshot passed to Observer's job lifecycle methods. Err is only set for
+// JobFailed/JobDead/JobRetryScheduled; Duration is only meaningful once the job has finished running.
+type JobEvent struct {
+	Job      *Job
+	Attempt  int
+	Duration time.Duration
+	Err      error
+}
+
+// snapshotJob copies j's exported fields into a detached Job with no pool or transaction, so an
+// Observer can read it but cannot use it to change the job in the database.
+func snapshotJob(j *Job) *Job {
+	return &Job{ID: j.ID, Queue: j.Queue, Priority: j.Priority, RunAt: j.RunAt, Type: j.Type,
+		Args: append([]byte(nil), j.Args...), Key: j.Key, ErrorCount: j.ErrorCount, LastError: j.LastError,
+		CreatedAt: j.CreatedAt, UpdatedAt: j.UpdatedAt, FinishedAt: j.FinishedAt}
+}
+
+// WorkerEvent is the snapshot passed to Observer's worker lifecycle methods. Err is only set for
+// PollError.
+type WorkerEvent struct {
+	WorkerID string
+	Queue    string
+	Err      error
+}
+
+// Observer receives typed, read-only notifications for queue lifecycle events. Unlike HookFunc, which
+// is handed the live *Job and can be used to mutate state from within a lifecycle callback, Observer
+// methods get an immutable snapshot, making them a better fit for metrics/tracing adapters such as
+// Prometheus or OpenTelemetry that should never be able to affect job processing.
+type Observer interface {
+	// JobStarted is called right before a locked job's WorkFunc is invoked.
+	JobStarted(ctx context.Context, event JobEvent)
+	// JobSucceeded is called after a job's WorkFunc returns nil.
+	JobSucceeded(ctx context.Context, event JobEvent)
+	// JobFailed is called after a job's WorkFunc returns a non-nil error and the job still has retries
+	// left, i.e. it was rescheduled via Job.Error rather than moved to the DLQ.
+	JobFailed(ctx context.Context, event JobEvent)
+	// JobRetryScheduled is called once a failed job's next run_at has been committed.
+	JobRetryScheduled(ctx context.Context, event JobEvent)
+	// JobDead is called after a job is moved to the dead-letter queue via Job.Dead.
+	JobDead(ctx context.Context, event JobEvent)
+	// PollError is called when a worker's attempt to lock a job fails with something other than "no job
+	// found".
+	PollError(ctx context.Context, event WorkerEvent)
+	// WorkerStarted is called once when a Worker's Run loop begins.
+	WorkerStarted(ctx context.Context, event WorkerEvent)
+	// WorkerStopped is called once when a Worker's Run loop returns.
+	WorkerStopped(ctx context.Context, event WorkerEvent)
+}
+
+// NoopObserver implements Observer with no-op methods. It is the default Observer for Worker/WorkerPool
+// so callers that don't configure one pay no cost and can embed it to implement only the methods they
+// care about.
+type NoopObserver struct{}
+
+func (NoopObserver) JobStarted(context.Context, JobEvent)        {}
+func (NoopObserver) JobSucceeded(context.Context, JobEvent)      {}
+func (NoopObserver) JobFailed(context.Context, JobEvent)         {}
+func (NoopObserver) JobRetryScheduled(context.Context, JobEvent) {}
+func (NoopObserver) JobDead(context.Context, JobEvent)           {}
+func (NoopObserver) PollError(context.Context, WorkerEvent)      {}
+func (NoopObserver) WorkerStarted(context.Context, WorkerEvent)  {}
+func (NoopObserver) WorkerStopped(context.Context, WorkerEvent)  {}
+
+// MultiObserver fans every Observer method out to each of its members, in order.
+type MultiObserver []Observer
+
+func (m MultiObserver) JobStarted(ctx context.Context, event JobEvent) {
+	for _, o := range m {
+		o.JobStarted(ctx, event)
+	}
+}
+
+func (m MultiObserver) JobSucceeded(ctx context.Context, event JobEvent) {
+	for _, o := range m {
+		o.JobSucceeded(ctx, event)
+	}
+}
+
+func (m MultiObserver) JobFailed(ctx context.Context, event JobEvent) {
+	for _, o := range m {
+		o.JobFailed(ctx, event)
+	}
+}
+
+func (m MultiObserver) JobRetryScheduled(ctx context.Context, event JobEvent) {
+	for _, o := range m {
+		o.JobRetryScheduled(ctx, event)
+	}
+}
+
+func (m MultiObserver) JobDead(ctx context.Context, event JobEvent) {
+	for _, o := range m {
+		o.JobDead(ctx, event)
+	}
+}
+
+func (m MultiObserver) PollError(ctx context.Context, event WorkerEvent) {
+	for _, o := range m {
+		o.PollError(ctx, event)
+	}
+}
+
+func (m MultiObserver) WorkerStarted(ctx context.Context, event WorkerEvent) {
+	for _, o := range m {
+		o.WorkerStarted(ctx, event)
+	}
+}
+
+func (m MultiObserver) WorkerStopped(ctx context.Context, event WorkerEvent) {
+	for _, o := range m {
+		o.WorkerStopped(ctx, event)
+	}
+}
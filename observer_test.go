@@ -0,0 +1,188 @@
+package gue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockObserver struct {
+	mock.Mock
+}
+
+func (m *mockObserver) JobStarted(ctx context.Context, event JobEvent)        { m.Called(ctx, event) }
+func (m *mockObserver) JobSucceeded(ctx context.Context, event JobEvent)      { m.Called(ctx, event) }
+func (m *mockObserver) JobFailed(ctx context.Context, event JobEvent)         { m.Called(ctx, event) }
+func (m *mockObserver) JobRetryScheduled(ctx context.Context, event JobEvent) { m.Called(ctx, event) }
+func (m *mockObserver) JobDead(ctx context.Context, event JobEvent)           { m.Called(ctx, event) }
+func (m *mockObserver) PollError(ctx context.Context, event WorkerEvent)      { m.Called(ctx, event) }
+func (m *mockObserver) WorkerStarted(ctx context.Context, event WorkerEvent)  { m.Called(ctx, event) }
+func (m *mockObserver) WorkerStopped(ctx context.Context, event WorkerEvent)  { m.Called(ctx, event) }
+
+func TestNoopObserver(t *testing.T) {
+	assert.NotPanics(t, func() {
+		var o Observer = NoopObserver{}
+		ctx := context.Background()
+		o.JobStarted(ctx, JobEvent{})
+		o.JobSucceeded(ctx, JobEvent{})
+		o.JobFailed(ctx, JobEvent{})
+		o.JobRetryScheduled(ctx, JobEvent{})
+		o.JobDead(ctx, JobEvent{})
+		o.PollError(ctx, WorkerEvent{})
+		o.WorkerStarted(ctx, WorkerEvent{})
+		o.WorkerStopped(ctx, WorkerEvent{})
+	})
+}
+
+func TestMultiObserver(t *testing.T) {
+	ctx := context.Background()
+	jobEvent := JobEvent{Err: errors.New("boom")}
+	workerEvent := WorkerEvent{WorkerID: "w1"}
+
+	first := new(mockObserver)
+	second := new(mockObserver)
+	for _, o := range []*mockObserver{first, second} {
+		o.On("JobStarted", ctx, jobEvent)
+		o.On("JobSucceeded", ctx, jobEvent)
+		o.On("JobFailed", ctx, jobEvent)
+		o.On("JobRetryScheduled", ctx, jobEvent)
+		o.On("JobDead", ctx, jobEvent)
+		o.On("PollError", ctx, workerEvent)
+		o.On("WorkerStarted", ctx, workerEvent)
+		o.On("WorkerStopped", ctx, workerEvent)
+	}
+
+	multi := MultiObserver{first, second}
+	multi.JobStarted(ctx, jobEvent)
+	multi.JobSucceeded(ctx, jobEvent)
+	multi.JobFailed(ctx, jobEvent)
+	multi.JobRetryScheduled(ctx, jobEvent)
+	multi.JobDead(ctx, jobEvent)
+	multi.PollError(ctx, workerEvent)
+	multi.WorkerStarted(ctx, workerEvent)
+	multi.WorkerStopped(ctx, workerEvent)
+
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
+
+func TestWithWorkerObserver(t *testing.T) {
+	workerWithDefaultObserver := NewWorker(nil, dummyWM)
+	assert.IsType(t, NoopObserver{}, workerWithDefaultObserver.observer)
+
+	observer := new(mockObserver)
+	workerWithCustomObserver := NewWorker(nil, dummyWM, WithWorkerObserver(observer))
+	assert.Equal(t, observer, workerWithCustomObserver.observer)
+}
+
+func TestWithPoolObserver(t *testing.T) {
+	observer := new(mockObserver)
+	workerPoolWithCustomObserver := NewWorkerPool(nil, dummyWM, 2, WithPoolObserver(observer))
+	assert.Equal(t, observer, workerPoolWithCustomObserver.observer)
+}
+
+func newRunnableJob(t *testing.T, execErr error) *Job {
+	t.Helper()
+
+	pool := newFakePool()
+	txIface, err := pool.Begin(context.Background())
+	require.NoError(t, err)
+
+	tx := txIface.(*fakeTx)
+	tx.execErr = execErr
+
+	return &Job{ID: 1, Queue: "default", Type: "MyJob", pool: pool, tx: tx, backoff: defaultBackoff}
+}
+
+func TestWorker_RunJob_NotifiesJobStartedAndJobSucceeded(t *testing.T) {
+	observer := new(mockObserver)
+	observer.On("JobStarted", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Attempt == 1 }))
+	observer.On("JobSucceeded", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Attempt == 1 }))
+
+	w := NewWorker(nil, dummyWM, WithWorkerObserver(observer))
+	j := newRunnableJob(t, nil)
+
+	w.runJob(context.Background(), j, func(ctx context.Context, j *Job) error { return nil })
+
+	observer.AssertExpectations(t)
+}
+
+func TestWorker_RunJob_NotifiesJobFailedAndRetryScheduled(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	observer := new(mockObserver)
+	observer.On("JobStarted", mock.Anything, mock.Anything)
+	observer.On("JobFailed", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Err == wantErr }))
+	observer.On("JobRetryScheduled", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Err == wantErr }))
+
+	w := NewWorker(nil, dummyWM, WithWorkerObserver(observer), WithWorkerMaxRetries(0))
+	j := newRunnableJob(t, nil)
+
+	w.runJob(context.Background(), j, func(ctx context.Context, j *Job) error { return wantErr })
+
+	observer.AssertExpectations(t)
+	observer.AssertNotCalled(t, "JobDead", mock.Anything, mock.Anything)
+}
+
+func TestWorker_RunJob_NotifiesJobDeadOnceMaxRetriesReached(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	observer := new(mockObserver)
+	observer.On("JobStarted", mock.Anything, mock.Anything)
+	observer.On("JobDead", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Err == wantErr }))
+
+	w := NewWorker(nil, dummyWM, WithWorkerObserver(observer), WithWorkerMaxRetries(1))
+	j := newRunnableJob(t, nil)
+
+	w.runJob(context.Background(), j, func(ctx context.Context, j *Job) error { return wantErr })
+
+	observer.AssertExpectations(t)
+	observer.AssertNotCalled(t, "JobFailed", mock.Anything, mock.Anything)
+}
+
+func TestWorker_RunJob_SuppressesRetryScheduledWhenPersistFails(t *testing.T) {
+	wantErr := errors.New("boom")
+	persistErr := errors.New("update failed")
+
+	observer := new(mockObserver)
+	observer.On("JobStarted", mock.Anything, mock.Anything)
+	observer.On("JobFailed", mock.Anything, mock.MatchedBy(func(e JobEvent) bool { return e.Err == wantErr }))
+
+	w := NewWorker(nil, dummyWM, WithWorkerObserver(observer), WithWorkerMaxRetries(0))
+	j := newRunnableJob(t, persistErr)
+
+	w.runJob(context.Background(), j, func(ctx context.Context, j *Job) error { return wantErr })
+
+	observer.AssertExpectations(t)
+	observer.AssertNotCalled(t, "JobRetryScheduled", mock.Anything, mock.Anything)
+}
+
+func TestWorker_RunJob_SuppressesJobDeadWhenPersistFails(t *testing.T) {
+	wantErr := errors.New("boom")
+	persistErr := errors.New("insert failed")
+
+	observer := new(mockObserver)
+	observer.On("JobStarted", mock.Anything, mock.Anything)
+
+	w := NewWorker(nil, dummyWM, WithWorkerObserver(observer), WithWorkerMaxRetries(1))
+	j := newRunnableJob(t, persistErr)
+
+	w.runJob(context.Background(), j, func(ctx context.Context, j *Job) error { return wantErr })
+
+	observer.AssertNotCalled(t, "JobDead", mock.Anything, mock.Anything)
+}
+
+func TestWorker_WorkOne_NotifiesPollError(t *testing.T) {
+	observer := new(mockObserver)
+	observer.On("PollError", mock.Anything, mock.MatchedBy(func(e WorkerEvent) bool { return e.Err != nil }))
+
+	c := NewClient(newFakePool())
+	w := NewWorker(c, dummyWM, WithWorkerObserver(observer))
+
+	assert.False(t, w.workOne(context.Background()))
+	observer.AssertExpectations(t)
+}
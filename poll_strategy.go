@@ -0,0 +1,13 @@
+package gue
+
+// PollStrategy determines how a worker waits between attempts to lock its next job. Under every
+// strategy, Client.LockJob picks jobs in order of priority, then run_at.
+type PollStrategy string
+
+const (
+	// PriorityPollStrategy polls for available jobs every poll interval.
+	PriorityPollStrategy PollStrategy = "priority_poll_strategy"
+	// RunAtPollStrategy polls every poll interval exactly like PriorityPollStrategy; jobs are not locked
+	// in order of run_at under it.
+	RunAtPollStrategy PollStrategy = "run_at_poll_strategy"
+)
@@ -0,0 +1,90 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// reviveStalledReason is recorded as the last_error on a job the janitor reclaims from a worker that
+// stopped sending heartbeats, most likely because it crashed or was OOM-killed mid-job.
+const reviveStalledReason = "revived after stall"
+
+// janitor periodically reclaims jobs whose locked_at heartbeat has gone stale, so a worker that died
+// mid-job doesn't hold its lock forever. A revived job is treated exactly like a normal Job.Error: its
+// error_count is bumped and run_at rescheduled via backoff, so retry limits and the dead-letter queue
+// still apply to it.
+type janitor struct {
+	pool           adapter.ConnPool
+	logger         adapter.Logger
+	queue          string
+	stallThreshold time.Duration
+	backoff        Backoff
+}
+
+// newJanitor builds a janitor that reclaims jobs locked on queue for longer than stallThreshold.
+func newJanitor(pool adapter.ConnPool, logger adapter.Logger, queue string, stallThreshold time.Duration, backoff Backoff) *janitor {
+	return &janitor{
+		pool:           pool,
+		logger:         logger,
+		queue:          queue,
+		stallThreshold: stallThreshold,
+		backoff:        backoff,
+	}
+}
+
+// run scans for and revives stalled jobs every interval until ctx is done. It is meant to be started in
+// its own goroutine by a Worker configured via WithWorkerReviveStalled.
+func (jn *janitor) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := jn.reviveStalled(ctx); err != nil {
+				jn.logger.Error("failed to revive stalled jobs", adapter.F("error", err))
+			}
+		}
+	}
+}
+
+// reviveStalled clears the lock on every job whose locked_at heartbeat is older than stallThreshold and
+// reschedules it with backoff, mirroring what Job.Error does for a normally-failed job.
+//
+// adapter.ConnPool has no multi-row Query, only QueryRow, so this looks up and revives one stalled job at
+// a time instead of scanning a result set: each revival clears the job's locked_at, which is exactly the
+// condition the lookup filters on, so repeating the single-row lookup until it reports sql.ErrNoRows
+// covers every stalled job without needing pagination.
+func (jn *janitor) reviveStalled(ctx context.Context) error {
+	for {
+		var id int64
+		var errorCount int32
+
+		err := jn.pool.QueryRow(ctx, `SELECT job_id, error_count FROM gue_jobs
+WHERE queue = $1 AND locked_at IS NOT NULL AND locked_at < now() - ($2 * INTERVAL '1 second')
+ORDER BY locked_at ASC
+LIMIT 1`, jn.queue, jn.stallThreshold.Seconds()).Scan(&id, &errorCount)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		errorCount++
+		runAt := time.Now().UTC().Add(jn.backoff(int(errorCount)))
+
+		if _, err := jn.pool.Exec(ctx, `UPDATE gue_jobs
+SET error_count = $1, run_at = $2, last_error = $3, locked_at = NULL, locked_by = NULL, updated_at = now()
+WHERE job_id = $4 AND locked_at IS NOT NULL`, errorCount, runAt, reviveStalledReason, id); err != nil {
+			jn.logger.Error("failed to revive stalled job", adapter.F("job_id", id), adapter.F("error", err))
+			return err
+		}
+	}
+}
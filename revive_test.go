@@ -0,0 +1,37 @@
+package gue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+func TestJanitor_ReviveStalled_NoStalledJobs(t *testing.T) {
+	pool := newFakePool()
+	jn := newJanitor(pool, adapter.NoOpLogger{}, "", time.Minute, defaultBackoff)
+
+	assert.NoError(t, jn.reviveStalled(context.Background()))
+}
+
+func TestJanitor_Run_StopsWithContext(t *testing.T) {
+	pool := newFakePool()
+	jn := newJanitor(pool, adapter.NoOpLogger{}, "", time.Minute, defaultBackoff)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		jn.run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor.run did not stop after ctx was cancelled")
+	}
+}
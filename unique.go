@@ -0,0 +1,108 @@
+package gue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// uniqueViolationCode is the Postgres error code for unique_violation, raised by
+// idx_gue_jobs_unique_key when enqueueUniqueInTx's check-then-insert loses a race against a concurrent
+// enqueue of the same (Queue, Type, Key).
+const uniqueViolationCode = "23505"
+
+// isUniqueKeyViolation reports whether err is the unique_violation idx_gue_jobs_unique_key raises.
+func isUniqueKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// ErrJobConflict is returned by Client.EnqueueUnique / Client.EnqueueUniqueInTx when a live job with the
+// same (Queue, Type, Key) already exists, so callers can build idempotent producers without resorting to
+// an ad-hoc SELECT before every enqueue.
+type ErrJobConflict struct {
+	Queue string
+	Type  string
+	Key   string
+}
+
+func (e *ErrJobConflict) Error() string {
+	return fmt.Sprintf("gue: job with queue=%q type=%q key=%q already exists", e.Queue, e.Type, e.Key)
+}
+
+// ClientOption defines a type that allows to set client properties during the build-time.
+type ClientOption func(*Client)
+
+// WithUniqueTTL makes Client.EnqueueUnique / Client.EnqueueUniqueInTx also treat a finished job with a
+// matching (Queue, Type, Key) as a live conflict until ttl has elapsed since it finished. Without this
+// option, uniqueness is only enforced while a matching job is unfinished.
+func WithUniqueTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.uniqueTTL = ttl
+	}
+}
+
+// EnqueueUnique inserts a job the same way Enqueue does, but fails with *ErrJobConflict instead of
+// creating a duplicate if a live job with the same (Queue, Type, Key) already exists. It requires
+// j.Key to be set.
+func (c *Client) EnqueueUnique(ctx context.Context, j *Job) error {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := c.enqueueUniqueInTx(ctx, j, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// EnqueueUniqueInTx is the transactional counterpart of EnqueueUnique: it inserts j using the given
+// transaction instead of opening its own, so callers can enqueue atomically alongside other writes.
+// The caller remains responsible for committing or rolling back tx.
+func (c *Client) EnqueueUniqueInTx(ctx context.Context, j *Job, tx adapter.Tx) error {
+	return c.enqueueUniqueInTx(ctx, j, tx)
+}
+
+func (c *Client) enqueueUniqueInTx(ctx context.Context, j *Job, tx adapter.Tx) error {
+	if j.Key == "" {
+		return fmt.Errorf("gue: EnqueueUnique requires a non-empty Job.Key")
+	}
+
+	var conflict bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS (
+    SELECT 1 FROM gue_jobs
+    WHERE queue = $1 AND job_type = $2 AND key = $3
+      AND (finished_at IS NULL OR finished_at > now() - ($4 * INTERVAL '1 second'))
+) OR EXISTS (
+    SELECT 1 FROM gue_jobs_finished
+    WHERE queue = $1 AND job_type = $2 AND key = $3
+      AND finished_at > now() - ($4 * INTERVAL '1 second')
+)`, j.Queue, j.Type, j.Key, c.uniqueTTL.Seconds()).Scan(&conflict)
+	if err != nil {
+		return err
+	}
+	if conflict {
+		return &ErrJobConflict{Queue: j.Queue, Type: j.Type, Key: j.Key}
+	}
+
+	// The SELECT above can still race a concurrent enqueue of the same (Queue, Type, Key): both callers
+	// may see no conflict before either inserts. idx_gue_jobs_unique_key closes that gap at the database
+	// level, so translate the resulting unique_violation into the same *ErrJobConflict a caller would
+	// have gotten had it lost the SELECT race instead.
+	if err := c.enqueueInTx(ctx, j, tx); err != nil {
+		if isUniqueKeyViolation(err) {
+			return &ErrJobConflict{Queue: j.Queue, Type: j.Type, Key: j.Key}
+		}
+		return err
+	}
+
+	return nil
+}
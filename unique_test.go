@@ -0,0 +1,59 @@
+package gue
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrJobConflict_Error(t *testing.T) {
+	err := &ErrJobConflict{Queue: "default", Type: "MyJob", Key: "customer-42"}
+	assert.Equal(t, `gue: job with queue="default" type="MyJob" key="customer-42" already exists`, err.Error())
+}
+
+func TestWithUniqueTTL(t *testing.T) {
+	c := new(Client)
+	WithUniqueTTL(5 * defaultPollInterval)(c)
+	assert.Equal(t, 5*defaultPollInterval, c.uniqueTTL)
+}
+
+// TestEnqueueUnique_ConcurrentRace forces two EnqueueUnique calls for the same (Queue, Type, Key) through
+// enqueueUniqueInTx's check-then-insert race window together: both pass the EXISTS check before either
+// inserts, so the race can only be resolved by idx_gue_jobs_unique_key rejecting the loser's insert.
+// Exactly one caller must succeed and the other must get *ErrJobConflict, not a raw driver error.
+func TestEnqueueUnique_ConcurrentRace(t *testing.T) {
+	pool := newFakePool()
+
+	var atBarrier sync.WaitGroup
+	atBarrier.Add(2)
+	pool.afterSelect = func() { atBarrier.Done(); atBarrier.Wait() }
+
+	c := NewClient(pool)
+
+	results := make(chan error, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- c.EnqueueUnique(context.Background(), &Job{Queue: "q", Type: "MyJob", Key: "customer-42"})
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var successes, conflicts int
+	for err := range results {
+		switch {
+		case err == nil:
+			successes++
+		case assert.ErrorAs(t, err, new(*ErrJobConflict)):
+			conflicts++
+		}
+	}
+
+	assert.Equal(t, 1, successes)
+	assert.Equal(t, 1, conflicts)
+}
@@ -0,0 +1,10 @@
+package gue
+
+import "context"
+
+// WorkFunc is a function that performs a Job. The context carries the deadline set via
+// WithWorkerJobTimeout, if any.
+type WorkFunc func(ctx context.Context, j *Job) error
+
+// WorkMap is a map of job types to the WorkFunc that should be used to work them.
+type WorkMap map[string]WorkFunc
@@ -0,0 +1,271 @@
+package gue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// Worker is a single worker that locks and works jobs from one queue using one WorkMap.
+type Worker struct {
+	wm WorkMap
+	c  *Client
+
+	interval     time.Duration
+	queue        string
+	id           string
+	logger       adapter.Logger
+	pollStrategy PollStrategy
+
+	preserveCompletedJobs bool
+	migrateCompletedJob   bool
+
+	hooksJobLocked      []HookFunc
+	hooksUnknownJobType []HookFunc
+	hooksJobDone        []HookFunc
+	hooksJobDead        []HookFunc
+
+	notifyConnFactory func(ctx context.Context) (NotifyConn, error)
+
+	maxRetries            int
+	dlqQueue              string
+	jobTimeout            time.Duration
+	reviveStalledInterval time.Duration
+
+	observer Observer
+}
+
+// NewWorker returns a new Worker that locks jobs from c using strategies and hooks set by options, and
+// works them with the WorkFunc from wm matching their type.
+func NewWorker(c *Client, wm WorkMap, options ...WorkerOption) *Worker {
+	w := Worker{
+		wm:           wm,
+		c:            c,
+		interval:     defaultPollInterval,
+		queue:        defaultQueueName,
+		id:           randomID("worker"),
+		logger:       adapter.NoOpLogger{},
+		pollStrategy: PriorityPollStrategy,
+		observer:     NoopObserver{},
+	}
+
+	for _, option := range options {
+		option(&w)
+	}
+
+	w.logger = w.logger.With(adapter.F("worker-id", w.id))
+
+	return &w
+}
+
+// Run locks and works jobs from w's queue until ctx is done, then returns nil. A NotifyPollStrategy
+// listener that fails to connect or LISTEN is logged and retried every second; Run does not return it.
+func (w *Worker) Run(ctx context.Context) error {
+	w.logger.Info("Worker starting")
+	defer w.logger.Info("Worker stopping")
+
+	workerEvent := WorkerEvent{WorkerID: w.id, Queue: w.queue}
+	w.observer.WorkerStarted(ctx, workerEvent)
+	defer w.observer.WorkerStopped(ctx, workerEvent)
+
+	wake, stopNotify := w.startNotifyListener(ctx)
+	defer stopNotify()
+
+	stopJanitor := w.startJanitor(ctx)
+	defer stopJanitor()
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		for w.workOne(ctx) {
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+
+		interval := w.interval
+		if wake != nil {
+			interval = notifyFallbackInterval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		case <-wake:
+			timer.Stop()
+		}
+	}
+}
+
+// startNotifyListener starts the acquirer backing NotifyPollStrategy, if configured. It returns a
+// channel that receives a value whenever a job matching one of w's types is enqueued (nil if
+// NotifyPollStrategy is not in use), and a func to stop the listener.
+func (w *Worker) startNotifyListener(ctx context.Context) (wake <-chan struct{}, stop func()) {
+	if w.pollStrategy != NotifyPollStrategy {
+		return nil, func() {}
+	}
+
+	wakeCh := make(chan struct{}, 1)
+	a := newAcquirer(w.logger)
+
+	unregister := make([]func(), 0, len(w.wm))
+	for jobType := range w.wm {
+		unregister = append(unregister, a.register(w.queue, jobType, wakeCh))
+	}
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	go a.listen(listenCtx, w.connectNotify)
+
+	return wakeCh, func() {
+		cancel()
+		for _, u := range unregister {
+			u()
+		}
+	}
+}
+
+// connectNotify returns the connection the worker's acquirer LISTENs on, sourced from the factory set via
+// WithWorkerNotifyConn. adapter.ConnPool has no way to hand out a raw connection, so NotifyPollStrategy
+// requires this to be configured explicitly; omitting it is an error.
+func (w *Worker) connectNotify(ctx context.Context) (NotifyConn, error) {
+	if w.notifyConnFactory == nil {
+		return nil, fmt.Errorf("worker[id=%s] NotifyPollStrategy requires WithWorkerNotifyConn/WithPoolNotifyConn", w.id)
+	}
+	return w.notifyConnFactory(ctx)
+}
+
+// startJanitor starts the stalled-job janitor backing WithWorkerReviveStalled, if configured. It
+// returns a no-op stop func when reviveStalledInterval is unset.
+func (w *Worker) startJanitor(ctx context.Context) (stop func()) {
+	if w.reviveStalledInterval <= 0 {
+		return func() {}
+	}
+
+	jn := newJanitor(w.c.pool, w.logger, w.queue, w.stallThreshold(), w.c.backoff)
+	janitorCtx, cancel := context.WithCancel(ctx)
+	go jn.run(janitorCtx, w.reviveStalledInterval)
+
+	return cancel
+}
+
+// stallThreshold is how long a job can go without a heartbeat before the janitor reclaims it. It is
+// kept comfortably above defaultHeartbeatInterval, and never below jobTimeout itself, so a job that is
+// simply running long within its own deadline is not mistaken for stalled.
+func (w *Worker) stallThreshold() time.Duration {
+	threshold := 3 * defaultHeartbeatInterval
+	if w.jobTimeout > threshold {
+		threshold = w.jobTimeout
+	}
+	return threshold
+}
+
+// workOne locks and works at most one job. It returns true if it locked a job (so the caller should
+// immediately try for another, rather than wait out the poll interval), false if there was no job to
+// lock or locking failed. A job of unknown type is not worked: it is rescheduled via Job.Error.
+func (w *Worker) workOne(ctx context.Context) (didWork bool) {
+	j, err := w.c.LockJob(ctx, w.queue, w.id)
+	if err != nil {
+		w.logger.Error("Worker failed to lock a job", adapter.F("error", err))
+		callHooks(ctx, w.hooksJobLocked, nil, err)
+		w.observer.PollError(ctx, WorkerEvent{WorkerID: w.id, Queue: w.queue, Err: err})
+		return false
+	}
+	callHooks(ctx, w.hooksJobLocked, j, nil)
+	if j == nil {
+		return false
+	}
+
+	wf, ok := w.wm[j.Type]
+	if !ok {
+		err := fmt.Errorf("worker[id=%s] unknown job type: %q", w.id, j.Type)
+		w.logger.Error("Got a job with unknown type", adapter.F("job-type", j.Type))
+		callHooks(ctx, w.hooksUnknownJobType, j, err)
+		if retryErr := j.Error(ctx, err.Error()); retryErr != nil {
+			w.logger.Error("Failed to reschedule unknown-type job", adapter.F("error", retryErr))
+		}
+		return true
+	}
+
+	w.runJob(ctx, j, wf)
+	return true
+}
+
+// runJob runs wf against the already-locked job j, then updates it according to the outcome and
+// notifies hooks/the observer. It is split out from workOne so the outcome-handling logic can be tested
+// directly against a job that didn't come from a real LockJob.
+func (w *Worker) runJob(ctx context.Context, j *Job, wf WorkFunc) {
+	workCtx := ctx
+	if w.jobTimeout > 0 {
+		var cancel context.CancelFunc
+		workCtx, cancel = context.WithTimeout(ctx, w.jobTimeout)
+		defer cancel()
+
+		stopHeartbeat := startHeartbeat(ctx, w.c.pool, j.ID, defaultHeartbeatInterval, w.logger)
+		defer stopHeartbeat()
+	}
+
+	attempt := int(j.ErrorCount) + 1
+	w.observer.JobStarted(ctx, JobEvent{Job: snapshotJob(j), Attempt: attempt})
+	start := time.Now()
+
+	workErr := wf(workCtx, j)
+	duration := time.Since(start)
+
+	if workErr == nil {
+		if err := w.finishJob(ctx, j); err != nil {
+			w.logger.Error("Failed to finish job", adapter.F("error", err))
+		}
+		callHooks(ctx, w.hooksJobDone, j, nil)
+		w.observer.JobSucceeded(ctx, JobEvent{Job: snapshotJob(j), Attempt: attempt, Duration: duration})
+		return
+	}
+
+	if shouldDeadLetter(j.ErrorCount, w.maxRetries) {
+		if w.dlqQueue != "" {
+			j.Queue = w.dlqQueue
+		}
+		deadErr := j.Dead(ctx, workErr.Error())
+		if deadErr != nil {
+			w.logger.Error("Failed to move job to dead-letter queue", adapter.F("error", deadErr))
+		}
+		callHooks(ctx, w.hooksJobDead, j, workErr)
+		callHooks(ctx, w.hooksJobDone, j, workErr)
+		if deadErr == nil {
+			w.observer.JobDead(ctx, JobEvent{Job: snapshotJob(j), Attempt: attempt, Duration: duration, Err: workErr})
+		}
+		return
+	}
+
+	retryErr := j.Error(ctx, workErr.Error())
+	if retryErr != nil {
+		w.logger.Error("Failed to schedule job retry", adapter.F("error", retryErr))
+	}
+	callHooks(ctx, w.hooksJobDone, j, workErr)
+	w.observer.JobFailed(ctx, JobEvent{Job: snapshotJob(j), Attempt: attempt, Duration: duration, Err: workErr})
+	if retryErr == nil {
+		w.observer.JobRetryScheduled(ctx, JobEvent{Job: snapshotJob(j), Attempt: attempt, Duration: duration, Err: workErr})
+	}
+}
+
+// finishJob marks j as done according to the worker's preserveCompletedJobs/migrateCompletedJob config.
+func (w *Worker) finishJob(ctx context.Context, j *Job) error {
+	if err := j.Finished(ctx); err != nil {
+		return err
+	}
+	if w.migrateCompletedJob {
+		if err := j.Migrate(ctx); err != nil {
+			return err
+		}
+	}
+	if !w.preserveCompletedJobs {
+		return j.Delete(ctx)
+	}
+	return nil
+}
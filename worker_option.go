@@ -1,6 +1,7 @@
 package gue
 
 import (
+	"context"
 	"time"
 
 	"github.com/vgarvardt/gue/v3/adapter"
@@ -80,6 +81,59 @@ func WithWorkerHooksJobDone(hooks ...HookFunc) WorkerOption {
 	}
 }
 
+// WithWorkerHooksJobDead sets hooks that are called when a job is moved to the dead-letter queue after
+// exhausting its retries. Error field is always set to the error the job failed with on its last attempt.
+func WithWorkerHooksJobDead(hooks ...HookFunc) WorkerOption {
+	return func(w *Worker) {
+		w.hooksJobDead = hooks
+	}
+}
+
+// WithWorkerMaxRetries sets the number of attempts (including the first one) a job gets before it is
+// moved to the dead-letter queue instead of being rescheduled. The zero value keeps the default
+// behaviour of retrying forever with backoff.
+func WithWorkerMaxRetries(maxRetries int) WorkerOption {
+	return func(w *Worker) {
+		w.maxRetries = maxRetries
+	}
+}
+
+// WithWorkerDLQQueue overrides the default dead-letter queue name used when a job is moved to
+// gue_jobs_dlq after exhausting WithWorkerMaxRetries.
+func WithWorkerDLQQueue(queue string) WorkerOption {
+	return func(w *Worker) {
+		w.dlqQueue = queue
+	}
+}
+
+// WithWorkerJobTimeout bounds how long a single WorkFunc call is allowed to run: the context passed to
+// it is cancelled after d, and the worker starts sending a locked_at heartbeat every
+// defaultHeartbeatInterval so a janitor started via WithWorkerReviveStalled can tell the job is still
+// being worked on. The zero value leaves jobs with no deadline, matching prior behaviour.
+func WithWorkerJobTimeout(d time.Duration) WorkerOption {
+	return func(w *Worker) {
+		w.jobTimeout = d
+	}
+}
+
+// WithWorkerObserver sets the Observer notified of queue lifecycle events for this worker. The default
+// is NoopObserver. Use MultiObserver to wire up more than one.
+func WithWorkerObserver(observer Observer) WorkerOption {
+	return func(w *Worker) {
+		w.observer = observer
+	}
+}
+
+// WithWorkerReviveStalled starts a janitor goroutine that scans every interval for jobs whose locked_at
+// heartbeat has gone stale and clears their lock so another worker can pick them up. A revived job is
+// recorded with last_error "revived after stall" and goes through the normal backoff/DLQ path, turning
+// at-least-once execution into resilient recovery from a worker crash or OOM kill.
+func WithWorkerReviveStalled(interval time.Duration) WorkerOption {
+	return func(w *Worker) {
+		w.reviveStalledInterval = interval
+	}
+}
+
 // WithWorkerPollStrategy overrides default poll strategy with given value
 func WithWorkerPollStrategy(s PollStrategy) WorkerOption {
 	return func(w *Worker) {
@@ -87,6 +141,17 @@ func WithWorkerPollStrategy(s PollStrategy) WorkerOption {
 	}
 }
 
+// WithWorkerNotifyConn sets the factory a worker uses to open the dedicated connection it LISTENs on for
+// gue_jobs notifications when using NotifyPollStrategy. adapter.ConnPool has no way to hand out a raw
+// connection, so the caller must supply one directly (e.g. backed by a single long-lived *pgx.Conn); the
+// worker calls connect again to reconnect if the connection drops, and never shares it with anything
+// else.
+func WithWorkerNotifyConn(connect func(ctx context.Context) (NotifyConn, error)) WorkerOption {
+	return func(w *Worker) {
+		w.notifyConnFactory = connect
+	}
+}
+
 // WithPoolPollInterval overrides default poll interval with the given value.
 // Poll interval is the "sleep" duration if there were no jobs found in the DB.
 func WithPoolPollInterval(d time.Duration) WorkerPoolOption {
@@ -136,6 +201,16 @@ func WithPoolPollStrategy(s PollStrategy) WorkerPoolOption {
 	}
 }
 
+// WithPoolNotifyConn sets the factory each worker in the pool uses to open its own dedicated connection
+// for LISTENing for gue_jobs notifications when using NotifyPollStrategy. adapter.ConnPool has no way to
+// hand out a raw connection, so this must be set for NotifyPollStrategy to work; connFactory is called
+// by each worker, lazily, when that worker starts listening, and again whenever it reconnects.
+func WithPoolNotifyConn(connFactory func(ctx context.Context) (NotifyConn, error)) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.notifyConnFactory = connFactory
+	}
+}
+
 // WithPoolHooksJobLocked calls WithWorkerHooksJobLocked for every worker in the pool.
 func WithPoolHooksJobLocked(hooks ...HookFunc) WorkerPoolOption {
 	return func(w *WorkerPool) {
@@ -156,3 +231,45 @@ func WithPoolHooksJobDone(hooks ...HookFunc) WorkerPoolOption {
 		w.hooksJobDone = hooks
 	}
 }
+
+// WithPoolHooksJobDead calls WithWorkerHooksJobDead for every worker in the pool.
+func WithPoolHooksJobDead(hooks ...HookFunc) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.hooksJobDead = hooks
+	}
+}
+
+// WithPoolMaxRetries calls WithWorkerMaxRetries for every worker in the pool.
+func WithPoolMaxRetries(maxRetries int) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.maxRetries = maxRetries
+	}
+}
+
+// WithPoolDLQQueue calls WithWorkerDLQQueue for every worker in the pool.
+func WithPoolDLQQueue(queue string) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.dlqQueue = queue
+	}
+}
+
+// WithPoolJobTimeout calls WithWorkerJobTimeout for every worker in the pool.
+func WithPoolJobTimeout(d time.Duration) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.jobTimeout = d
+	}
+}
+
+// WithPoolReviveStalled calls WithWorkerReviveStalled for every worker in the pool.
+func WithPoolReviveStalled(interval time.Duration) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.reviveStalledInterval = interval
+	}
+}
+
+// WithPoolObserver calls WithWorkerObserver for every worker in the pool.
+func WithPoolObserver(observer Observer) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.observer = observer
+	}
+}
@@ -88,6 +88,39 @@ func TestWithWorkerPollStrategy(t *testing.T) {
 	assert.Equal(t, RunAtPollStrategy, workerWithWorkerPollStrategy.pollStrategy)
 }
 
+func TestWithWorkerMaxRetries(t *testing.T) {
+	workerWithDefaultMaxRetries := NewWorker(nil, dummyWM)
+	assert.Zero(t, workerWithDefaultMaxRetries.maxRetries)
+
+	customMaxRetries := 5
+	workerWithCustomMaxRetries := NewWorker(nil, dummyWM, WithWorkerMaxRetries(customMaxRetries))
+	assert.Equal(t, customMaxRetries, workerWithCustomMaxRetries.maxRetries)
+}
+
+func TestWithWorkerDLQQueue(t *testing.T) {
+	customDLQQueue := "fooBarBazDLQ"
+	workerWithCustomDLQQueue := NewWorker(nil, dummyWM, WithWorkerDLQQueue(customDLQQueue))
+	assert.Equal(t, customDLQQueue, workerWithCustomDLQQueue.dlqQueue)
+}
+
+func TestWithWorkerJobTimeout(t *testing.T) {
+	workerWithDefaultJobTimeout := NewWorker(nil, dummyWM)
+	assert.Zero(t, workerWithDefaultJobTimeout.jobTimeout)
+
+	customJobTimeout := 30 * time.Second
+	workerWithCustomJobTimeout := NewWorker(nil, dummyWM, WithWorkerJobTimeout(customJobTimeout))
+	assert.Equal(t, customJobTimeout, workerWithCustomJobTimeout.jobTimeout)
+}
+
+func TestWithWorkerReviveStalled(t *testing.T) {
+	workerWithDefaultReviveStalled := NewWorker(nil, dummyWM)
+	assert.Zero(t, workerWithDefaultReviveStalled.reviveStalledInterval)
+
+	customInterval := time.Minute
+	workerWithCustomReviveStalled := NewWorker(nil, dummyWM, WithWorkerReviveStalled(customInterval))
+	assert.Equal(t, customInterval, workerWithCustomReviveStalled.reviveStalledInterval)
+}
+
 func TestWithPoolPollInterval(t *testing.T) {
 	workerPoolWithDefaultInterval := NewWorkerPool(nil, dummyWM, 2)
 	assert.Equal(t, defaultPollInterval, workerPoolWithDefaultInterval.interval)
@@ -137,6 +170,30 @@ func TestWithPoolPollStrategy(t *testing.T) {
 	assert.Equal(t, RunAtPollStrategy, workerPoolWithPoolPollStrategy.pollStrategy)
 }
 
+func TestWithPoolMaxRetries(t *testing.T) {
+	customMaxRetries := 5
+	workerPoolWithCustomMaxRetries := NewWorkerPool(nil, dummyWM, 2, WithPoolMaxRetries(customMaxRetries))
+	assert.Equal(t, customMaxRetries, workerPoolWithCustomMaxRetries.maxRetries)
+}
+
+func TestWithPoolDLQQueue(t *testing.T) {
+	customDLQQueue := "fooBarBazDLQ"
+	workerPoolWithCustomDLQQueue := NewWorkerPool(nil, dummyWM, 2, WithPoolDLQQueue(customDLQQueue))
+	assert.Equal(t, customDLQQueue, workerPoolWithCustomDLQQueue.dlqQueue)
+}
+
+func TestWithPoolJobTimeout(t *testing.T) {
+	customJobTimeout := 30 * time.Second
+	workerPoolWithCustomJobTimeout := NewWorkerPool(nil, dummyWM, 2, WithPoolJobTimeout(customJobTimeout))
+	assert.Equal(t, customJobTimeout, workerPoolWithCustomJobTimeout.jobTimeout)
+}
+
+func TestWithPoolReviveStalled(t *testing.T) {
+	customInterval := time.Minute
+	workerPoolWithCustomReviveStalled := NewWorkerPool(nil, dummyWM, 2, WithPoolReviveStalled(customInterval))
+	assert.Equal(t, customInterval, workerPoolWithCustomReviveStalled.reviveStalledInterval)
+}
+
 type dummyHook struct {
 	counter int
 }
@@ -0,0 +1,121 @@
+package gue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v3/adapter"
+)
+
+// WorkerPool is a collection of Workers, each working jobs from the same queue/WorkMap with the same
+// configuration, for increased throughput.
+type WorkerPool struct {
+	wm      WorkMap
+	c       *Client
+	workers []*Worker
+
+	interval     time.Duration
+	queue        string
+	id           string
+	logger       adapter.Logger
+	pollStrategy PollStrategy
+
+	preserveCompletedJobs bool
+	migrateCompletedJob   bool
+
+	hooksJobLocked      []HookFunc
+	hooksUnknownJobType []HookFunc
+	hooksJobDone        []HookFunc
+	hooksJobDead        []HookFunc
+
+	notifyConnFactory func(ctx context.Context) (NotifyConn, error)
+
+	maxRetries            int
+	dlqQueue              string
+	jobTimeout            time.Duration
+	reviveStalledInterval time.Duration
+
+	observer Observer
+}
+
+// NewWorkerPool returns a new WorkerPool with poolSize Workers, each configured identically by options.
+func NewWorkerPool(c *Client, wm WorkMap, poolSize int, options ...WorkerPoolOption) *WorkerPool {
+	p := WorkerPool{
+		wm:           wm,
+		c:            c,
+		interval:     defaultPollInterval,
+		queue:        defaultQueueName,
+		id:           randomID("worker-pool"),
+		logger:       adapter.NoOpLogger{},
+		pollStrategy: PriorityPollStrategy,
+		observer:     NoopObserver{},
+	}
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	p.logger = p.logger.With(adapter.F("worker-pool-id", p.id))
+
+	p.workers = make([]*Worker, poolSize)
+	for i := range p.workers {
+		workerOptions := []WorkerOption{
+			WithWorkerPollInterval(p.interval),
+			WithWorkerQueue(p.queue),
+			WithWorkerID(fmt.Sprintf("%s-%d", p.id, i)),
+			WithWorkerLogger(p.logger),
+			WithWorkerPollStrategy(p.pollStrategy),
+			WithWorkerPreserveCompletedJobs(p.preserveCompletedJobs),
+			WithWorkerMigrateCompletedJobs(p.migrateCompletedJob),
+			WithWorkerHooksJobLocked(p.hooksJobLocked...),
+			WithWorkerHooksUnknownJobType(p.hooksUnknownJobType...),
+			WithWorkerHooksJobDone(p.hooksJobDone...),
+			WithWorkerHooksJobDead(p.hooksJobDead...),
+			WithWorkerMaxRetries(p.maxRetries),
+			WithWorkerDLQQueue(p.dlqQueue),
+			WithWorkerJobTimeout(p.jobTimeout),
+			WithWorkerReviveStalled(p.reviveStalledInterval),
+			WithWorkerObserver(p.observer),
+		}
+
+		if p.notifyConnFactory != nil {
+			workerOptions = append(workerOptions, WithWorkerNotifyConn(p.notifyConnFactory))
+		}
+
+		p.workers[i] = NewWorker(c, wm, workerOptions...)
+	}
+
+	return &p
+}
+
+// Run starts every worker in the pool and blocks until ctx is done and they have all returned.
+func (p *WorkerPool) Run(ctx context.Context) error {
+	p.logger.Info("Worker pool starting")
+	defer p.logger.Info("Worker pool stopping")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(p.workers))
+
+	for _, worker := range p.workers {
+		wg.Add(1)
+		go func(worker *Worker) {
+			defer wg.Done()
+			if err := worker.Run(ctx); err != nil {
+				errs <- err
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}